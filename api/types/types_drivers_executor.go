@@ -43,6 +43,35 @@ const (
 
 	// LSXCmdUmount is the command for unmounting mounted file systems.
 	LSXCmdUmount = "umount"
+
+	// LSXCmdGetMounts is the command for listing the mounts known to the
+	// executor.
+	LSXCmdGetMounts = "getMounts"
+
+	// LSXCmdFormat is the command for formatting a device with a file system.
+	LSXCmdFormat = "format"
+
+	// LSXCmdFSInfo is the command for probing a device's file system.
+	LSXCmdFSInfo = "fsInfo"
+
+	// LSXCmdFCHostWWNs is the command for getting the local host's FC
+	// initiator WWNs.
+	LSXCmdFCHostWWNs = "fcHostWWNs"
+
+	// LSXCmdISCSIInitiators is the command for getting the local host's
+	// iSCSI initiator names.
+	LSXCmdISCSIInitiators = "iscsiInitiators"
+
+	// LSXCmdRescanSCSIBus is the command for rescanning the local SCSI bus.
+	LSXCmdRescanSCSIBus = "rescanSCSIBus"
+
+	// LSXCmdHostDevices is the command for dumping the host's device
+	// inventory (USB/PCI/GPU) as JSON.
+	LSXCmdHostDevices = "hostDevices"
+
+	// FCPortPrefix is the prefix a Fibre Channel host port name must have
+	// in /sys/class/fc_host/host*/port_name to be considered a valid WWN.
+	FCPortPrefix = "0x50"
 )
 
 const (
@@ -52,6 +81,11 @@ const (
 
 	// DeviceScanDeep performs a deep, longer scan.
 	DeviceScanDeep
+
+	// DeviceScanMultipath performs a scan that additionally collapses any
+	// device presented via multiple paths (as is typical for FC/iSCSI
+	// LUNs) into a single entry keyed by its SCSI WWN.
+	DeviceScanMultipath
 )
 
 // String returns the string representation of a DeviceScanType.
@@ -61,6 +95,8 @@ func (st DeviceScanType) String() string {
 		return "quick"
 	case DeviceScanDeep:
 		return "deep"
+	case DeviceScanMultipath:
+		return "multipath"
 	}
 	return ""
 }
@@ -74,6 +110,8 @@ func ParseDeviceScanType(i interface{}) DeviceScanType {
 			return DeviceScanQuick
 		} else if lti == DeviceScanDeep.String() {
 			return DeviceScanDeep
+		} else if lti == DeviceScanMultipath.String() {
+			return DeviceScanMultipath
 		}
 		i, err := strconv.Atoi(ti)
 		if err != nil {
@@ -82,7 +120,7 @@ func ParseDeviceScanType(i interface{}) DeviceScanType {
 		return ParseDeviceScanType(i)
 	case int:
 		st := DeviceScanType(ti)
-		if st == DeviceScanQuick || st == DeviceScanDeep {
+		if st == DeviceScanQuick || st == DeviceScanDeep || st == DeviceScanMultipath {
 			return st
 		}
 		return DeviceScanQuick
@@ -95,6 +133,62 @@ func ParseDeviceScanType(i interface{}) DeviceScanType {
 type LocalDevicesOpts struct {
 	ScanType DeviceScanType
 	Opts     Store
+
+	// Rescan indicates the SCSI bus should be rescanned prior to building
+	// the local devices list so that newly mapped LUNs are discovered
+	// without requiring a reboot.
+	Rescan bool
+}
+
+// LocalDevices is the result of a LocalDevices call. DeviceMap maps a
+// volume/device ID to its local device path. When the scan was performed
+// with DeviceScanMultipath, a device presented via several /dev/sd* paths
+// is collapsed into a single DeviceMap entry whose path is the dm-multipath
+// aggregate (/dev/mapper/mpath* or /dev/dm-N) if one is present, and
+// MultipathDevices is populated, keyed by SCSI WWN, with the full set of
+// underlying paths.
+type LocalDevices struct {
+	// DriverName is the name of the driver that provided the local
+	// devices.
+	DriverName string
+
+	// DeviceMap is a map of volume/device IDs to their local device path.
+	DeviceMap map[string]string
+
+	// MultipathDevices is a map of SCSI WWN to the multipath device that
+	// aggregates it. It is only populated when the scan was performed
+	// with DeviceScanMultipath.
+	MultipathDevices map[string]*MultipathDevice
+}
+
+// MultipathDevice describes a single logical volume presented to the host
+// via more than one path, such as a FC/iSCSI LUN visible through several
+// sd* devices.
+type MultipathDevice struct {
+	// WWN is the SCSI WWN that identifies the underlying logical volume,
+	// read from /sys/block/<dev>/device/wwid or a
+	// /dev/disk/by-id/wwn-0x* symlink.
+	WWN string
+
+	// Device is the canonical device path for this WWN: the dm-multipath
+	// device (/dev/mapper/mpath* or /dev/dm-N) when present, otherwise the
+	// first discovered raw path.
+	Device string
+
+	// Paths are all of the raw /dev/sd* paths that were collapsed into
+	// this entry.
+	Paths []string
+}
+
+// RescanOpts are options when rescanning the local SCSI bus.
+type RescanOpts struct {
+	// Target, Channel, and LUN identify a specific target/lun tuple to
+	// rescan. If left empty, the wildcard form ("- - -") is written to
+	// each /sys/class/scsi_host/hostN/scan node, rescanning everything
+	// visible to that host.
+	Target  string
+	Channel string
+	LUN     string
 }
 
 // WaitForDeviceOpts are options when waiting on specific local device to
@@ -104,7 +198,10 @@ type WaitForDeviceOpts struct {
 
 	// Token is the value returned by a remote VolumeAttach call that the
 	// client can use to block until a specific device has appeared in the
-	// local devices list.
+	// local devices list. Token may be a raw device ID or, when the
+	// underlying driver is FC/iSCSI, a SCSI WWN, in which case
+	// WaitForDevice blocks until either the raw path or the multipath
+	// aggregate for that WWN appears, whichever comes first.
 	Token string
 
 	// Timeout is the maximum duration for which to wait for a device to
@@ -156,6 +253,97 @@ type StorageExecutorWithSupported interface {
 		opts Store) (bool, error)
 }
 
+// DeviceMountOpts are options when mounting a device.
+type DeviceMountOpts struct {
+	// MountOptions is the string of options passed to mount.
+	MountOptions string
+
+	// MountLabel is the label applied to the mount.
+	MountLabel string
+
+	// NewFSType is the file system type to use if the device needs to be
+	// formatted.
+	NewFSType string
+
+	// OverwriteFS will overwrite an existing filesystem if set to true.
+	OverwriteFS bool
+
+	// Bind indicates the mount is a bind mount (mount(2) MS_BIND), in
+	// which case deviceName is treated as an existing path rather than a
+	// block device.
+	Bind bool
+
+	// Propagation is the mount propagation mode to apply: "shared",
+	// "slave", "private", or "unbindable". An empty value leaves the
+	// mount's propagation as created by the kernel.
+	Propagation string
+
+	// Remount indicates an already mounted device should be remounted
+	// with the flags described by this DeviceMountOpts (mount(2)
+	// MS_REMOUNT) rather than mounted fresh.
+	Remount bool
+
+	// ReadOnly indicates the mount should be read-only (mount(2)
+	// MS_RDONLY).
+	ReadOnly bool
+
+	// NoDiscard disables the discard/trim mount option even if the
+	// underlying file system driver would otherwise enable it.
+	NoDiscard bool
+
+	// RequestID correlates this mount with the rest of a CSI-style call
+	// chain across log lines.
+	RequestID string
+
+	// Opts are additional options.
+	Opts Store
+}
+
+// GetMountsOpts are options when listing mounts known to the executor.
+type GetMountsOpts struct {
+	// Device, if set, restricts the result to mounts of this source
+	// device.
+	Device string
+
+	// Path, if set, restricts the result to mounts at this target path.
+	Path string
+
+	// Opts are additional options.
+	Opts Store
+}
+
+// MountInfo describes a single mount point as parsed from the 9-field
+// /proc/self/mountinfo format.
+type MountInfo struct {
+	// ID is a unique ID for the mount.
+	ID int
+
+	// Parent is the ID of the parent mount.
+	Parent int
+
+	// Major and Minor are the major/minor device numbers of the backing
+	// device.
+	Major int
+	Minor int
+
+	// Root is the path of the directory in the filesystem that forms the
+	// root of this mount.
+	Root string
+
+	// Source is the mount source, such as a device path.
+	Source string
+
+	// MountPoint is the path to the mount point, relative to the process's
+	// root.
+	MountPoint string
+
+	// Options is the per-mount options.
+	Options string
+
+	// FSType is the file system type.
+	FSType string
+}
+
 // StorageExecutorWithMount is an interface that executor implementations
 // may use to become part of the mount/unmount workflow.
 type StorageExecutorWithMount interface {
@@ -173,6 +361,203 @@ type StorageExecutorWithMount interface {
 		opts Store) error
 }
 
+// StorageExecutorWithGetMounts is an interface that executor implementations
+// may use to enumerate the mounts they know about, optionally filtered by
+// source device or target path, without forcing every
+// StorageExecutorWithMount implementation to also support it.
+type StorageExecutorWithGetMounts interface {
+
+	// GetMounts lists the mounts known to the executor, optionally
+	// filtered by source device or target path.
+	GetMounts(
+		ctx Context,
+		opts *GetMountsOpts) ([]*MountInfo, error)
+}
+
+// DeviceFormatOpts are options when formatting a device with a file system.
+type DeviceFormatOpts struct {
+	// FSType is the type of file system to put on the device, such as
+	// ext4, xfs, or btrfs.
+	FSType string
+
+	// Label is the label to apply to the new file system.
+	Label string
+
+	// UUID is the UUID to assign to the new file system.
+	UUID string
+
+	// NoDiscard indicates mkfs should be invoked with its discard/trim
+	// option disabled (mkfs.ext4 "-K", mkfs.xfs "--nodiscard").
+	NoDiscard bool
+
+	// Discard indicates the device should be discarded (blkdiscard) prior
+	// to being formatted so thin-provisioned backends can reclaim space.
+	Discard bool
+
+	// Force indicates the device should be formatted even if it already
+	// has a file system matching FSType.
+	Force bool
+
+	// Overwrite indicates an existing, unmounted file system of a
+	// different type may be overwritten.
+	Overwrite bool
+
+	// MkfsArgs are additional, free-form arguments passed to mkfs.
+	MkfsArgs []string
+}
+
+// FSInfo describes the file system detected on a device.
+type FSInfo struct {
+	// FSType is the detected file system type.
+	FSType string
+
+	// Label is the detected file system label.
+	Label string
+
+	// UUID is the detected file system UUID.
+	UUID string
+
+	// UsedBytes is the number of bytes used on the file system.
+	UsedBytes int64
+
+	// TotalBytes is the total capacity of the file system in bytes.
+	TotalBytes int64
+}
+
+// StorageExecutorWithFormat is an interface that executor implementations
+// may use to become part of the format/probe workflow for local block
+// devices, such as those freshly attached but not yet usable.
+type StorageExecutorWithFormat interface {
+
+	// Format formats a device with the requested file system. Format is a
+	// no-op if the device already has a file system matching the requested
+	// FSType, unless opts.Force is set, and it refuses to format a device
+	// that is currently mounted.
+	Format(
+		ctx Context,
+		deviceName string,
+		opts *DeviceFormatOpts) error
+
+	// FSInfo probes a device and returns information about the file system
+	// found there, such as via blkid or lsblk.
+	FSInfo(
+		ctx Context,
+		deviceName string) (*FSInfo, error)
+}
+
+// StorageExecutorWithTransport is an interface that executor implementations
+// may use to advertise the host's SAN identity so drivers that attach
+// volumes over Fibre Channel or iSCSI can report the initiator info the
+// server side of libStorage needs in order to map a new volume.
+type StorageExecutorWithTransport interface {
+
+	// FCHostWWNs returns the Fibre Channel initiator WWNs of the local
+	// host, read from /sys/class/fc_host/host*/port_name.
+	FCHostWWNs(ctx Context) ([]string, error)
+
+	// ISCSIInitiators returns the iSCSI initiator names of the local host,
+	// parsed from /etc/iscsi/initiatorname.iscsi.
+	ISCSIInitiators(ctx Context) ([]string, error)
+}
+
+// StorageExecutorWithRescan is an interface that executor implementations
+// may use to rescan the local SCSI bus without also having to report a
+// FC/iSCSI transport identity, such as a SAS or other directly-attached
+// SCSI driver that only needs newly mapped LUNs to be enumerated on
+// demand.
+type StorageExecutorWithRescan interface {
+
+	// RescanSCSIBus rescans the local SCSI bus, causing newly attached
+	// LUNs to be enumerated without a reboot.
+	RescanSCSIBus(
+		ctx Context,
+		opts *RescanOpts) error
+}
+
+// HostDevicesOpts are options when getting a host's device inventory.
+type HostDevicesOpts struct {
+	Opts Store
+}
+
+// USBDevice describes a device enumerated under
+// /sys/bus/usb/devices/*.
+type USBDevice struct {
+	// VendorID is the device's idVendor.
+	VendorID string
+
+	// ProductID is the device's idProduct.
+	ProductID string
+
+	// Bus is the device's busnum.
+	Bus int
+
+	// Device is the device's devnum.
+	Device int
+
+	// SysPath is the device's path under /sys/bus/usb/devices.
+	SysPath string
+}
+
+// PCIDevice describes a device enumerated under
+// /sys/bus/pci/devices/*.
+type PCIDevice struct {
+	// BDF is the device's PCI address in Bus:Device.Function form.
+	BDF string
+
+	// VendorID and DeviceID are the device's PCI vendor/device IDs.
+	VendorID string
+	DeviceID string
+
+	// VendorName and DeviceName are resolved from a pcidb-style vendor
+	// database when available.
+	VendorName string
+	DeviceName string
+
+	// Class is the device's PCI class.
+	Class string
+
+	// SysPath is the device's path under /sys/bus/pci/devices.
+	SysPath string
+}
+
+// GPUDevice describes an NVIDIA or DRI GPU device node.
+type GPUDevice struct {
+	// Card is the /dev/nvidiaN or /dev/dri/cardN node path.
+	Card string
+
+	// RenderNode is the corresponding /dev/dri/renderD* node, if any.
+	RenderNode string
+
+	// Major and Minor are the device node's major/minor numbers.
+	Major int
+	Minor int
+
+	// NVRMVersion and CUDAVersion are parsed from
+	// /proc/driver/nvidia/version for NVIDIA devices. They are empty for
+	// non-NVIDIA DRI devices.
+	NVRMVersion string
+	CUDAVersion string
+}
+
+// HostDevices is the structured device inventory of the local host.
+type HostDevices struct {
+	USB []*USBDevice
+	PCI []*PCIDevice
+	GPU []*GPUDevice
+}
+
+// StorageExecutorWithHostDevices is an interface that executor
+// implementations may use to report the host's passthrough-capable device
+// inventory, such as for drivers that back onto hypervisors supporting
+// USB, PCI, or GPU passthrough alongside volume attachment.
+type StorageExecutorWithHostDevices interface {
+
+	// HostDevices returns the host's USB, PCI, and GPU device inventory.
+	HostDevices(
+		ctx Context,
+		opts *HostDevicesOpts) (*HostDevices, error)
+}
+
 // ProvidesStorageExecutorCLI is a type that provides the StorageExecutorCLI.
 type ProvidesStorageExecutorCLI interface {
 	// XCLI returns the StorageExecutorCLI.
@@ -226,6 +611,25 @@ const (
 
 	// LSXSOpUmount indicates an executor supports "Umount".
 	LSXSOpUmount
+
+	// LSXSOpGetMounts indicates an executor supports "GetMounts".
+	LSXSOpGetMounts
+
+	// LSXSOpFormat indicates an executor supports "Format".
+	LSXSOpFormat
+
+	// LSXSOpFSInfo indicates an executor supports "FSInfo".
+	LSXSOpFSInfo
+
+	// LSXSOpTransport indicates an executor supports "FCHostWWNs" and
+	// "ISCSIInitiators".
+	LSXSOpTransport
+
+	// LSXSOpRescan indicates an executor supports "RescanSCSIBus".
+	LSXSOpRescan
+
+	// LSXSOpHostDevices indicates an executor supports "HostDevices".
+	LSXSOpHostDevices
 )
 
 const (
@@ -238,7 +642,13 @@ const (
 		LSXSOpLocalDevices |
 		LSXSOpWaitForDevice |
 		LSXSOpMount |
-		LSXSOpUmount
+		LSXSOpUmount |
+		LSXSOpGetMounts |
+		LSXSOpFormat |
+		LSXSOpFSInfo |
+		LSXSOpTransport |
+		LSXSOpRescan |
+		LSXSOpHostDevices
 
 	// LSXOpAllNoMount indicates the executor supports all operations except
 	// mount and unmount.
@@ -281,6 +691,42 @@ func (v LSXSupportedOp) Umount() bool {
 	return v.bitSet(LSXSOpUmount)
 }
 
+// GetMounts returns a flag that indicates whether the LSXSOpGetMounts bit
+// is set.
+func (v LSXSupportedOp) GetMounts() bool {
+	return v.bitSet(LSXSOpGetMounts)
+}
+
+// Format returns a flag that indicates whether the LSXSOpFormat bit
+// is set.
+func (v LSXSupportedOp) Format() bool {
+	return v.bitSet(LSXSOpFormat)
+}
+
+// FSInfo returns a flag that indicates whether the LSXSOpFSInfo bit
+// is set.
+func (v LSXSupportedOp) FSInfo() bool {
+	return v.bitSet(LSXSOpFSInfo)
+}
+
+// Transport returns a flag that indicates whether the LSXSOpTransport bit
+// is set.
+func (v LSXSupportedOp) Transport() bool {
+	return v.bitSet(LSXSOpTransport)
+}
+
+// Rescan returns a flag that indicates whether the LSXSOpRescan bit
+// is set.
+func (v LSXSupportedOp) Rescan() bool {
+	return v.bitSet(LSXSOpRescan)
+}
+
+// HostDevices returns a flag that indicates whether the LSXSOpHostDevices
+// bit is set.
+func (v LSXSupportedOp) HostDevices() bool {
+	return v.bitSet(LSXSOpHostDevices)
+}
+
 func (v LSXSupportedOp) bitSet(b LSXSupportedOp) bool {
 	return v&b == b
 }